@@ -73,6 +73,86 @@ func Startup(out *opsmngr.AutomationConfig, name string) {
 	setDisabledByClusterName(out, name, false)
 }
 
+// TLSSettings holds the net.tls settings applied to every process of a cluster, along with the
+// automation config's top-level SSL block used by the agents to reach those processes.
+type TLSSettings struct {
+	Mode                  string
+	CAFile                string
+	PEMKeyFile            string
+	PEMKeyPassword        string
+	AllowInvalidHostnames bool
+	ClusterFile           string
+	DisabledProtocols     []string
+}
+
+// ConfigureTLS applies tls to every process belonging to the replica set or sharded cluster name,
+// and updates the automation config's top-level SSL block accordingly.
+func ConfigureTLS(out *opsmngr.AutomationConfig, name string, tls TLSSettings) error {
+	if out == nil {
+		return errors.New("the Automation Config has not been initialized")
+	}
+	setTLSByClusterName(out, name, tls)
+	out.SSL = &opsmngr.SSL{
+		CAFilePath:            tls.CAFile,
+		AutoPEMKeyFilePath:    tls.PEMKeyFile,
+		ClientCertificateMode: clientCertificateModeOptional,
+	}
+	return nil
+}
+
+// DisableTLS resets the net.tls settings of every process belonging to the replica set or sharded
+// cluster name, and replaces the automation config's top-level SSL block with one that has no CA or
+// PEM key file path and an OPTIONAL client certificate mode.
+func DisableTLS(out *opsmngr.AutomationConfig, name string) error {
+	if out == nil {
+		return errors.New("the Automation Config has not been initialized")
+	}
+	setTLSByClusterName(out, name, TLSSettings{Mode: tlsModeDisabled})
+	out.SSL = &opsmngr.SSL{ClientCertificateMode: clientCertificateModeOptional}
+	return nil
+}
+
+func setTLSByClusterName(out *opsmngr.AutomationConfig, name string, tls TLSSettings) {
+	setTLSByReplicaSetName(out, name, tls)
+	setTLSByShardName(out, name, tls)
+}
+
+func setTLSByReplicaSetName(out *opsmngr.AutomationConfig, name string, tls TLSSettings) {
+	i, found := search.ReplicaSets(out.ReplicaSets, func(rs *opsmngr.ReplicaSet) bool {
+		return rs.ID == name
+	})
+	if found {
+		rs := out.ReplicaSets[i]
+		for _, m := range rs.Members {
+			for k, p := range out.Processes {
+				if p.Name == m.Host {
+					out.Processes[k].Args26.Net.TLS = opsmngr.TLS{
+						Mode:                  tls.Mode,
+						CAFile:                tls.CAFile,
+						PEMKeyFile:            tls.PEMKeyFile,
+						PEMKeyPassword:        tls.PEMKeyPassword,
+						AllowInvalidHostnames: tls.AllowInvalidHostnames,
+						ClusterFile:           tls.ClusterFile,
+						DisabledProtocols:     tls.DisabledProtocols,
+					}
+				}
+			}
+		}
+	}
+}
+
+func setTLSByShardName(out *opsmngr.AutomationConfig, name string, tls TLSSettings) {
+	i, found := search.ShardingConfig(out.Sharding, func(s *opsmngr.ShardingConfig) bool {
+		return s.Name == name
+	})
+	if found {
+		s := out.Sharding[i]
+		for _, rs := range s.Shards {
+			setTLSByReplicaSetName(out, rs.ID, tls)
+		}
+	}
+}
+
 // RemoveByClusterName removes a cluster and its associated processes from the config.
 // This won't shutdown any running process.
 func RemoveByClusterName(out *opsmngr.AutomationConfig, name string) {
@@ -119,15 +199,132 @@ func AddUser(out *opsmngr.AutomationConfig, u *opsmngr.MongoDBUser) {
 	out.Auth.Users = append(out.Auth.Users, u)
 }
 
-// ConfigureScramCredentials creates both SCRAM-SHA-1 and SCRAM-SHA-256 credentials.
-// Use this method to guarantee that password can be updated later.
+// AddX509User adds a opsmngr.MongoDBUser authenticated via MONGODB-X509 to the opsmngr.AutomationConfig.
+// The user is created in the $external database, as required by client-certificate auth, and carries
+// no SCRAM credentials.
+func AddX509User(out *opsmngr.AutomationConfig, subject string, roles []opsmngr.Role) {
+	addExternalUser(out, subject, mongoDBX509, roles)
+}
+
+// addExternalUser adds a opsmngr.MongoDBUser authenticated via an external mechanism to the
+// opsmngr.AutomationConfig. The user is created in the $external database and carries no SCRAM
+// credentials.
+func addExternalUser(out *opsmngr.AutomationConfig, username, mechanism string, roles []opsmngr.Role) {
+	u := &opsmngr.MongoDBUser{
+		Username:   username,
+		Database:   externalDB,
+		Roles:      roles,
+		Mechanisms: []string{mechanism},
+	}
+	AddUser(out, u)
+}
+
+// LDAPConfig holds the settings needed to authenticate and authorize users against an LDAP server.
+type LDAPConfig struct {
+	Servers            string
+	BindMethod         string
+	BindSaslMechanisms string
+	BindQueryUser      string
+	BindQueryPassword  string
+	TransportSecurity  string
+	CAFile             string
+	UserToDNMapping    string
+	AuthzQueryTemplate string
+}
+
+// ConfigureLDAP sets the LDAP authentication and authorization settings on the opsmngr.AutomationConfig.
+func ConfigureLDAP(out *opsmngr.AutomationConfig, cfg LDAPConfig) {
+	out.Ldap = &opsmngr.Ldap{
+		Servers:            cfg.Servers,
+		BindMethod:         cfg.BindMethod,
+		BindSaslMechanisms: cfg.BindSaslMechanisms,
+		BindQueryUser:      cfg.BindQueryUser,
+		BindQueryPassword:  cfg.BindQueryPassword,
+		TransportSecurity:  cfg.TransportSecurity,
+		CAFile:             cfg.CAFile,
+		UserToDNMapping:    cfg.UserToDNMapping,
+		AuthzQueryTemplate: cfg.AuthzQueryTemplate,
+	}
+}
+
+// AddLDAPUser adds a opsmngr.MongoDBUser authenticated via PLAIN (LDAP) to the opsmngr.AutomationConfig.
+// The user is created in the $external database and carries no SCRAM credentials.
+func AddLDAPUser(out *opsmngr.AutomationConfig, username string, roles []opsmngr.Role) {
+	addExternalUser(out, username, plain, roles)
+}
+
+// AddAWSUser adds a opsmngr.MongoDBUser authenticated via MONGODB-AWS to the opsmngr.AutomationConfig.
+// The username is the IAM ARN of the role or user being granted access. The user is created in the
+// $external database and carries no SCRAM credentials.
+func AddAWSUser(out *opsmngr.AutomationConfig, arn string, roles []opsmngr.Role) {
+	addExternalUser(out, arn, mongoDBAWS, roles)
+}
+
+// OIDCProperties holds the settings needed to authenticate users against an OIDC identity provider.
+// ENVIRONMENT should be one of "azure", "gcp", "test", or left empty for a workforce identity federation
+// provider.
+type OIDCProperties struct {
+	Environment   string
+	TokenResource string
+	Principal     string
+	AllowedHosts  []string
+}
+
+// ConfigureOIDC appends an OIDC identity provider configuration to the opsmngr.AutomationConfig.
+func ConfigureOIDC(out *opsmngr.AutomationConfig, props OIDCProperties) {
+	out.Auth.OIDCProviderConfigs = append(out.Auth.OIDCProviderConfigs, &opsmngr.OIDCProviderConfig{
+		Environment:   props.Environment,
+		TokenResource: props.TokenResource,
+		Principal:     props.Principal,
+		AllowedHosts:  props.AllowedHosts,
+	})
+}
+
+// AddOIDCUser adds a opsmngr.MongoDBUser authenticated via MONGODB-OIDC to the opsmngr.AutomationConfig.
+// The user is created in the $external database and carries no SCRAM credentials.
+func AddOIDCUser(out *opsmngr.AutomationConfig, principal string, roles []opsmngr.Role) {
+	addExternalUser(out, principal, mongoDBOIDC, roles)
+}
+
+// minScramIterations is the lowest iteration count accepted for either SCRAM mechanism, matching the
+// floor the Go driver's SCRAM client enforces (WithMinIterations(4096)).
+const minScramIterations = 4096
+
+// ScramOptions customizes the iteration counts used when generating SCRAM credentials.
+type ScramOptions struct {
+	Sha256Iterations int
+	Sha1Iterations   int
+}
+
+// ConfigureScramCredentials creates both SCRAM-SHA-1 and SCRAM-SHA-256 credentials using the default
+// iteration counts. Use this method to guarantee that password can be updated later.
 func ConfigureScramCredentials(user *opsmngr.MongoDBUser, password string) error {
-	scram256Creds, err := newScramSha256Creds(user, password)
+	return ConfigureScramCredentialsWithOptions(user, password, ScramOptions{
+		Sha256Iterations: scramSha256Iterations,
+		Sha1Iterations:   scramSha1Iterations,
+	})
+}
+
+// ConfigureScramCredentialsWithOptions creates both SCRAM-SHA-1 and SCRAM-SHA-256 credentials using the
+// iteration counts in opts. Both counts must be at least minScramIterations, the floor the Go driver's
+// SCRAM client enforces.
+func ConfigureScramCredentialsWithOptions(user *opsmngr.MongoDBUser, password string, opts ScramOptions) error {
+	if hasExternalMechanism(user) {
+		return fmt.Errorf("cannot set SCRAM credentials for user '%s': authenticates via an external mechanism", user.Username)
+	}
+	if opts.Sha256Iterations < minScramIterations {
+		return fmt.Errorf("SCRAM-SHA-256 iterations must be at least %d, got %d", minScramIterations, opts.Sha256Iterations)
+	}
+	if opts.Sha1Iterations < minScramIterations {
+		return fmt.Errorf("SCRAM-SHA-1 iterations must be at least %d, got %d", minScramIterations, opts.Sha1Iterations)
+	}
+
+	scram256Creds, err := newScramSha256Creds(user, password, opts.Sha256Iterations)
 	if err != nil {
 		return err
 	}
 
-	scram1Creds, err := newScramSha1Creds(user, password)
+	scram1Creds, err := newScramSha1Creds(user, password, opts.Sha1Iterations)
 	if err != nil {
 		return err
 	}
@@ -136,24 +333,24 @@ func ConfigureScramCredentials(user *opsmngr.MongoDBUser, password string) error
 	return nil
 }
 
-func newScramSha1Creds(user *opsmngr.MongoDBUser, password string) (*opsmngr.ScramShaCreds, error) {
+func newScramSha1Creds(user *opsmngr.MongoDBUser, password string, iterations int) (*opsmngr.ScramShaCreds, error) {
 	scram1Salt, err := generateSalt(sha1.New)
 	if err != nil {
 		return nil, fmt.Errorf("error generating scramSha1 salt: %s", err)
 	}
-	scram1Creds, err := newScramShaCreds(scram1Salt, user.Username, password, mongoCR)
+	scram1Creds, err := newScramShaCreds(scram1Salt, user.Username, password, mongoCR, iterations)
 	if err != nil {
 		return nil, fmt.Errorf("error generating scramSha1Creds: %s", err)
 	}
 	return scram1Creds, nil
 }
 
-func newScramSha256Creds(user *opsmngr.MongoDBUser, password string) (*opsmngr.ScramShaCreds, error) {
+func newScramSha256Creds(user *opsmngr.MongoDBUser, password string, iterations int) (*opsmngr.ScramShaCreds, error) {
 	scram256Salt, err := generateSalt(sha256.New)
 	if err != nil {
 		return nil, fmt.Errorf("error generating scramSha256 salt: %s", err)
 	}
-	scram256Creds, err := newScramShaCreds(scram256Salt, user.Username, password, scramSha256)
+	scram256Creds, err := newScramShaCreds(scram256Salt, user.Username, password, scramSha256, iterations)
 	if err != nil {
 		return nil, fmt.Errorf("error generating scramSha256 creds: %s", err)
 	}
@@ -162,18 +359,15 @@ func newScramSha256Creds(user *opsmngr.MongoDBUser, password string) (*opsmngr.S
 
 // newScramShaCreds takes a plain text password and a specified mechanism name and generates
 // the ScramShaCreds which will be embedded into a MongoDBUser.
-func newScramShaCreds(salt []byte, username, password, mechanism string) (*opsmngr.ScramShaCreds, error) {
+func newScramShaCreds(salt []byte, username, password, mechanism string, iterations int) (*opsmngr.ScramShaCreds, error) {
 	if mechanism != scramSha256 && mechanism != mongoCR {
 		return nil, fmt.Errorf("unrecognized SCRAM-SHA format %s", mechanism)
 	}
 	var hashConstructor hashingFunc
-	iterations := 0
 	if mechanism == scramSha256 {
 		hashConstructor = sha256.New
-		iterations = scramSha256Iterations
 	} else if mechanism == mongoCR {
 		hashConstructor = sha1.New
-		iterations = scramSha1Iterations
 
 		// MONGODB-CR/SCRAM-SHA-1 requires the hash of the password being passed computeScramCredentials
 		// instead of the plain text password.
@@ -230,21 +424,69 @@ func RemoveUser(out *opsmngr.AutomationConfig, username, database string) error
 	return nil
 }
 
+// UpdateUser updates the Roles, Mechanisms, and CustomData of an existing opsmngr.MongoDBUser,
+// matched by (username, database). Use SetUserPassword to rotate a user's password.
+func UpdateUser(out *opsmngr.AutomationConfig, u *opsmngr.MongoDBUser) error {
+	pos, found := search.MongoDBUsers(out.Auth.Users, func(p *opsmngr.MongoDBUser) bool {
+		return p.Username == u.Username && p.Database == u.Database
+	})
+	if !found {
+		return fmt.Errorf("user '%s' not found for '%s'", u.Username, u.Database)
+	}
+	existing := out.Auth.Users[pos]
+	existing.Roles = u.Roles
+	existing.Mechanisms = u.Mechanisms
+	existing.CustomData = u.CustomData
+	// A user moved onto an external mechanism (MONGODB-X509, PLAIN, MONGODB-AWS, MONGODB-OIDC) carries
+	// no SCRAM credentials; drop any left over from a prior SCRAM-SHA-1/SCRAM-SHA-256 configuration.
+	if hasExternalMechanism(existing) {
+		existing.ScramSha256Creds = nil
+		existing.ScramSha1Creds = nil
+	}
+	return nil
+}
+
+// SetUserPassword rotates the password of an existing opsmngr.MongoDBUser, matched by (username, database).
+// SCRAM-SHA-1 credentials are derived from the MD5 digest of the plain text password, so they cannot be
+// recomputed from the stored credentials alone: both SCRAM-SHA-1 and SCRAM-SHA-256 credentials are
+// regenerated in place via ConfigureScramCredentials using fresh salts.
+func SetUserPassword(out *opsmngr.AutomationConfig, username, database, newPassword string) error {
+	pos, found := search.MongoDBUsers(out.Auth.Users, func(p *opsmngr.MongoDBUser) bool {
+		return p.Username == username && p.Database == database
+	})
+	if !found {
+		return fmt.Errorf("user '%s' not found for '%s'", username, database)
+	}
+	user := out.Auth.Users[pos]
+	if hasExternalMechanism(user) {
+		return fmt.Errorf("cannot rotate password for user '%s': authenticates via an external mechanism and carries no SCRAM credentials", username)
+	}
+	return ConfigureScramCredentials(user, newPassword)
+}
+
 const (
 	automationAgentName            = "mms-automation"
 	keyLength                      = 500
 	mongoCR                        = "MONGODB-CR"
 	scramSha256                    = "SCRAM-SHA-256"
+	mongoDBX509                    = "MONGODB-X509"
+	plain                          = "PLAIN"
+	mongoDBAWS                     = "MONGODB-AWS"
+	mongoDBOIDC                    = "MONGODB-OIDC"
+	externalDB                     = "$external"
 	atmAgentWindowsKeyFilePath     = "%SystemDrive%\\MMSAutomation\\versions\\keyfile"
 	atmAgentKeyFilePathInContainer = "/var/lib/mongodb-mms-automation/keyfile"
+	tlsModeDisabled                = "disabled"
+	clientCertificateModeOptional  = "OPTIONAL"
 )
 
 // EnableMechanism allows you to enable a given set of authentication mechanisms to an opsmngr.AutomationConfig.
-// This method currently only supports MONGODB-CR, and SCRAM-SHA-256
+// This method currently only supports MONGODB-CR, SCRAM-SHA-256, MONGODB-X509, PLAIN (LDAP), MONGODB-AWS,
+// and MONGODB-OIDC
 func EnableMechanism(out *opsmngr.AutomationConfig, m []string) error {
 	out.Auth.Disabled = false
 	for _, v := range m {
-		if v != mongoCR && v != scramSha256 {
+		if v != mongoCR && v != scramSha256 && v != mongoDBX509 && v != plain && v != mongoDBAWS && v != mongoDBOIDC {
 			return fmt.Errorf("unsupported mechanism %s", v)
 		}
 		if v == scramSha256 && out.Auth.AutoAuthMechanism == "" {
@@ -253,7 +495,9 @@ func EnableMechanism(out *opsmngr.AutomationConfig, m []string) error {
 		if !stringInSlice(out.Auth.DeploymentAuthMechanisms, v) {
 			out.Auth.DeploymentAuthMechanisms = append(out.Auth.DeploymentAuthMechanisms, v)
 		}
-		if !stringInSlice(out.Auth.AutoAuthMechanisms, v) {
+		// MONGODB-X509, PLAIN, MONGODB-AWS, and MONGODB-OIDC authenticate a user against an external
+		// identity, not the automation agent itself, so they're never offered as AutoAuthMechanisms.
+		if (v == mongoCR || v == scramSha256) && !stringInSlice(out.Auth.AutoAuthMechanisms, v) {
 			out.Auth.AutoAuthMechanisms = append(out.Auth.AutoAuthMechanisms, v)
 		}
 	}
@@ -298,3 +542,17 @@ func stringInSlice(a []string, x string) bool {
 	}
 	return false
 }
+
+// externalMechanisms are the mechanisms that authenticate a user against an external identity
+// (a client certificate, an LDAP server, IAM, or an OIDC provider) rather than a SCRAM credential
+// stored on the automation config; users created with one of these never carry SCRAM credentials.
+var externalMechanisms = []string{mongoDBX509, plain, mongoDBAWS, mongoDBOIDC}
+
+func hasExternalMechanism(user *opsmngr.MongoDBUser) bool {
+	for _, m := range user.Mechanisms {
+		if stringInSlice(externalMechanisms, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"testing"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func TestEnableMechanism(t *testing.T) {
+	tests := []struct {
+		name              string
+		mechanisms        []string
+		wantErr           bool
+		wantAutoMechanism string
+		wantAutoAuthList  []string
+	}{
+		{
+			name:              "SCRAM-SHA-256 is offered to the automation agent",
+			mechanisms:        []string{scramSha256},
+			wantAutoMechanism: scramSha256,
+			wantAutoAuthList:  []string{scramSha256},
+		},
+		{
+			name:              "MONGODB-CR is offered to the automation agent",
+			mechanisms:        []string{mongoCR},
+			wantAutoMechanism: "",
+			wantAutoAuthList:  []string{mongoCR},
+		},
+		{
+			name:              "external mechanisms are never offered to the automation agent",
+			mechanisms:        []string{mongoDBX509, plain, mongoDBAWS, mongoDBOIDC},
+			wantAutoMechanism: "",
+			wantAutoAuthList:  nil,
+		},
+		{
+			name:       "unsupported mechanism is rejected",
+			mechanisms: []string{"GSSAPI"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &opsmngr.AutomationConfig{}
+
+			err := EnableMechanism(out, tt.mechanisms)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("EnableMechanism() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EnableMechanism() unexpected error: %v", err)
+			}
+			if out.Auth.AutoAuthMechanism != tt.wantAutoMechanism {
+				t.Errorf("AutoAuthMechanism = %q, want %q", out.Auth.AutoAuthMechanism, tt.wantAutoMechanism)
+			}
+			if len(out.Auth.AutoAuthMechanisms) != len(tt.wantAutoAuthList) {
+				t.Errorf("AutoAuthMechanisms = %v, want %v", out.Auth.AutoAuthMechanisms, tt.wantAutoAuthList)
+			}
+			for _, m := range tt.mechanisms {
+				if !stringInSlice(out.Auth.DeploymentAuthMechanisms, m) {
+					t.Errorf("DeploymentAuthMechanisms = %v, want it to contain %q", out.Auth.DeploymentAuthMechanisms, m)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigureScramCredentialsWithOptions(t *testing.T) {
+	t.Run("rejects an iteration count below the minimum", func(t *testing.T) {
+		tests := []ScramOptions{
+			{Sha256Iterations: minScramIterations - 1, Sha1Iterations: minScramIterations},
+			{Sha256Iterations: minScramIterations, Sha1Iterations: minScramIterations - 1},
+		}
+		for _, opts := range tests {
+			user := &opsmngr.MongoDBUser{Username: "test"}
+			if err := ConfigureScramCredentialsWithOptions(user, "password", opts); err == nil {
+				t.Errorf("ConfigureScramCredentialsWithOptions(%+v) expected an error, got nil", opts)
+			}
+		}
+	})
+
+	t.Run("rejects users on an external mechanism", func(t *testing.T) {
+		for _, m := range externalMechanisms {
+			user := &opsmngr.MongoDBUser{Username: "test", Mechanisms: []string{m}}
+			err := ConfigureScramCredentialsWithOptions(user, "password", ScramOptions{
+				Sha256Iterations: minScramIterations,
+				Sha1Iterations:   minScramIterations,
+			})
+			if err == nil {
+				t.Errorf("ConfigureScramCredentialsWithOptions() with mechanism %q expected an error, got nil", m)
+			}
+		}
+	})
+
+	t.Run("generates both SCRAM-SHA-1 and SCRAM-SHA-256 creds at the minimum", func(t *testing.T) {
+		user := &opsmngr.MongoDBUser{Username: "test"}
+		err := ConfigureScramCredentialsWithOptions(user, "password", ScramOptions{
+			Sha256Iterations: minScramIterations,
+			Sha1Iterations:   minScramIterations,
+		})
+		if err != nil {
+			t.Fatalf("ConfigureScramCredentialsWithOptions() unexpected error: %v", err)
+		}
+		if user.ScramSha256Creds == nil || user.ScramSha1Creds == nil {
+			t.Errorf("expected both ScramSha256Creds and ScramSha1Creds to be set")
+		}
+	})
+}
+
+func newTestReplicaSet(id string, hosts ...string) (*opsmngr.ReplicaSet, []*opsmngr.Process) {
+	members := make([]opsmngr.Member, len(hosts))
+	processes := make([]*opsmngr.Process, len(hosts))
+	for i, h := range hosts {
+		members[i] = opsmngr.Member{Host: h}
+		processes[i] = &opsmngr.Process{Name: h}
+	}
+	return &opsmngr.ReplicaSet{ID: id, Members: members}, processes
+}
+
+func TestConfigureTLS(t *testing.T) {
+	rs, processes := newTestReplicaSet("rs0", "host0", "host1")
+	out := &opsmngr.AutomationConfig{
+		ReplicaSets: []*opsmngr.ReplicaSet{rs},
+		Processes:   processes,
+	}
+
+	tls := TLSSettings{Mode: "requireTLS", CAFile: "ca.pem", PEMKeyFile: "server.pem"}
+	if err := ConfigureTLS(out, "rs0", tls); err != nil {
+		t.Fatalf("ConfigureTLS() unexpected error: %v", err)
+	}
+	for _, p := range out.Processes {
+		if p.Args26.Net.TLS.Mode != tls.Mode {
+			t.Errorf("process %q TLS mode = %q, want %q", p.Name, p.Args26.Net.TLS.Mode, tls.Mode)
+		}
+	}
+	if out.SSL == nil || out.SSL.CAFilePath != tls.CAFile {
+		t.Errorf("SSL.CAFilePath = %v, want %q", out.SSL, tls.CAFile)
+	}
+
+	if err := ConfigureTLS(nil, "rs0", tls); err == nil {
+		t.Errorf("ConfigureTLS(nil, ...) expected an error, got nil")
+	}
+}
+
+func TestDisableTLS(t *testing.T) {
+	rs, processes := newTestReplicaSet("rs0", "host0")
+	out := &opsmngr.AutomationConfig{
+		ReplicaSets: []*opsmngr.ReplicaSet{rs},
+		Processes:   processes,
+	}
+	out.Processes[0].Args26.Net.TLS = opsmngr.TLS{Mode: "requireTLS"}
+
+	if err := DisableTLS(out, "rs0"); err != nil {
+		t.Fatalf("DisableTLS() unexpected error: %v", err)
+	}
+	if out.Processes[0].Args26.Net.TLS.Mode != tlsModeDisabled {
+		t.Errorf("TLS mode = %q, want %q", out.Processes[0].Args26.Net.TLS.Mode, tlsModeDisabled)
+	}
+
+	if err := DisableTLS(nil, "rs0"); err == nil {
+		t.Errorf("DisableTLS(nil, ...) expected an error, got nil")
+	}
+}